@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Garetonchick/github-user-activity/pkg/github"
+)
+
+const keepaliveInterval = 15 * time.Second
+
+// server shares a single github.Watcher across requests, so two clients
+// streaming the same username only cost one GitHub poll between them.
+type server struct {
+	client  *github.Client
+	watcher *github.Watcher
+}
+
+func (s *server) handleStream(w http.ResponseWriter, r *http.Request) {
+	user := r.URL.Query().Get("user")
+	if user == "" {
+		http.Error(w, "missing user query parameter", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel := s.watcher.Subscribe(user)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Printf("marshal event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	user := r.URL.Query().Get("user")
+	if user == "" {
+		http.Error(w, "missing user query parameter", http.StatusBadRequest)
+		return
+	}
+
+	events, err := s.client.GetUserEvents(r.Context(), user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		log.Printf("encode events: %v", err)
+	}
+}
+
+func main() {
+	const addr = ":8080"
+
+	client := github.NewClient(http.DefaultClient)
+	s := &server{
+		client:  client,
+		watcher: github.NewWatcher(client),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stream", s.handleStream)
+	mux.HandleFunc("/events", s.handleEvents)
+
+	log.Printf("listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
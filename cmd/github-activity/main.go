@@ -2,13 +2,11 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
-	"strconv"
 
 	"github.com/Garetonchick/github-user-activity/pkg/github"
 )
@@ -17,29 +15,22 @@ type EventsDigest struct {
 	CommitsPushed       map[string]int
 	LastIssueOpenedRepo string
 	LastStar            string
+	PRsOpened           map[string]int
+	ReleasesPublished   []string
+	CommentsAuthored    int
 }
 
 func MakeEventsDigest(events []github.Event) *EventsDigest {
 	var digest EventsDigest
 	digest.CommitsPushed = make(map[string]int)
+	digest.PRsOpened = make(map[string]int)
 
 	processPushEvent := func(e *github.Event) error {
-		var fields map[string]json.RawMessage
-		err := json.Unmarshal(e.Payload, &fields)
-		if err != nil {
-			return err
-		}
-
-		size, ok := fields["size"]
+		p, ok := e.AsPush()
 		if !ok {
-			return errors.New("no field \"size\" inside payload")
+			return errors.New("malformed PushEvent payload")
 		}
-		sz, err := strconv.ParseInt(string(size), 10, 64)
-		if err != nil {
-			return errors.New("field \"size\" is not an int")
-		}
-		digest.CommitsPushed[e.Repo.Name] += int(sz)
-
+		digest.CommitsPushed[e.Repo.Name] += p.Size
 		return nil
 	}
 
@@ -48,21 +39,11 @@ func MakeEventsDigest(events []github.Event) *EventsDigest {
 			return nil
 		}
 
-		var fields map[string]any
-		err := json.Unmarshal(e.Payload, &fields)
-		if err != nil {
-			return err
-		}
-
-		action, ok := fields["action"]
+		p, ok := e.AsIssues()
 		if !ok {
-			return errors.New("no field \"action\" inside payload")
+			return errors.New("malformed IssuesEvent payload")
 		}
-		saction, ok := action.(string)
-		if !ok {
-			return errors.New("field \"action\" is not a string")
-		}
-		if saction == "opened" {
+		if p.Action == "opened" {
 			digest.LastIssueOpenedRepo = e.Repo.Name
 		}
 		return nil
@@ -75,6 +56,39 @@ func MakeEventsDigest(events []github.Event) *EventsDigest {
 		digest.LastStar = e.Repo.Name
 	}
 
+	processPullRequestEvent := func(e *github.Event) error {
+		p, ok := e.AsPullRequest()
+		if !ok {
+			return errors.New("malformed PullRequestEvent payload")
+		}
+		if p.Action == "opened" {
+			digest.PRsOpened[e.Repo.Name]++
+		}
+		return nil
+	}
+
+	processReleaseEvent := func(e *github.Event) error {
+		p, ok := e.AsRelease()
+		if !ok {
+			return errors.New("malformed ReleaseEvent payload")
+		}
+		if p.Action == "published" {
+			digest.ReleasesPublished = append(digest.ReleasesPublished, e.Repo.Name+"@"+p.Release.TagName)
+		}
+		return nil
+	}
+
+	processIssueCommentEvent := func(e *github.Event) error {
+		p, ok := e.AsIssueComment()
+		if !ok {
+			return errors.New("malformed IssueCommentEvent payload")
+		}
+		if p.Action == "created" {
+			digest.CommentsAuthored++
+		}
+		return nil
+	}
+
 	processEvent := func(e *github.Event) error {
 		switch e.Type {
 		case "PushEvent":
@@ -83,6 +97,12 @@ func MakeEventsDigest(events []github.Event) *EventsDigest {
 			return processIssuesEvent(e)
 		case "WatchEvent":
 			processWatchEvent(e)
+		case "PullRequestEvent":
+			return processPullRequestEvent(e)
+		case "ReleaseEvent":
+			return processReleaseEvent(e)
+		case "IssueCommentEvent":
+			return processIssueCommentEvent(e)
 		default:
 		}
 		return nil
@@ -113,6 +133,18 @@ func PrintEventsDigest(digest *EventsDigest) {
 		was = true
 		fmt.Printf("Starred %s\n", digest.LastStar)
 	}
+	for repo, count := range digest.PRsOpened {
+		was = true
+		fmt.Printf("Opened %d pull requests in %s\n", count, repo)
+	}
+	for _, release := range digest.ReleasesPublished {
+		was = true
+		fmt.Printf("Published release %s\n", release)
+	}
+	if digest.CommentsAuthored > 0 {
+		was = true
+		fmt.Printf("Authored %d comments\n", digest.CommentsAuthored)
+	}
 
 	if !was {
 		fmt.Println("User has no activity")
@@ -120,17 +152,46 @@ func PrintEventsDigest(digest *EventsDigest) {
 }
 
 func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("Expected username")
+	usernames := os.Args[1:]
+	if len(usernames) == 0 {
+		log.Fatalf("Expected at least one username")
 	}
-	username := os.Args[1]
-	githubClient := github.NewClient(http.DefaultClient)
 
-	events, err := githubClient.GetUserEvents(context.Background(), username)
+	var opts []github.ClientOption
+	if cacheDir, err := github.DefaultCacheDir(); err == nil {
+		if cache, err := github.NewFSCache(cacheDir); err == nil {
+			opts = append(opts, github.WithCache(cache))
+		}
+	}
+	githubClient := github.NewClient(http.DefaultClient, opts...)
+
+	if len(usernames) == 1 {
+		events, err := githubClient.GetUserEvents(context.Background(), usernames[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		PrintEventsDigest(MakeEventsDigest(events))
+		return
+	}
+
+	eventsByUser, err := githubClient.GetUsersEvents(
+		context.Background(),
+		usernames,
+		github.WithOnUserError(func(user string, err error) error {
+			log.Printf("%s: %v", user, err)
+			return nil
+		}),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	digest := MakeEventsDigest(events)
-	PrintEventsDigest(digest)
+	for _, user := range usernames {
+		events, ok := eventsByUser[user]
+		if !ok {
+			continue
+		}
+		fmt.Printf("== %s ==\n", user)
+		PrintEventsDigest(MakeEventsDigest(events))
+	}
 }
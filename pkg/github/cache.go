@@ -0,0 +1,75 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// CacheEntry is what a Cache stores per request URL: the ETag GitHub last
+// returned and the events fetched up to and including that response.
+type CacheEntry struct {
+	ETag   string
+	Events []Event
+}
+
+// Cache lets GetUserEvents make conditional requests instead of refetching
+// a user's whole event history on every call.
+type Cache interface {
+	Load(key string) (entry CacheEntry, ok bool)
+	Store(key string, entry CacheEntry) error
+}
+
+// FSCache is a Cache backed by one JSON file per key under a directory.
+type FSCache struct {
+	dir string
+}
+
+// NewFSCache creates the cache directory if needed and returns an FSCache
+// rooted at it.
+func NewFSCache(dir string) (*FSCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FSCache{dir: dir}, nil
+}
+
+// DefaultCacheDir returns the XDG cache directory for this tool, honoring
+// $XDG_CACHE_HOME like os.UserCacheDir.
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "github-user-activity"), nil
+}
+
+func (c *FSCache) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *FSCache) Load(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *FSCache) Store(key string, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.entryPath(key), data, 0o644)
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFSCacheLoadStoreRoundTrip(t *testing.T) {
+	cache, err := NewFSCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFSCache: %v", err)
+	}
+
+	entry := CacheEntry{
+		ETag: `"an-etag"`,
+		Events: []Event{
+			{ID: "1", Type: "PushEvent", Payload: json.RawMessage(`{"ref":"refs/heads/main"}`)},
+			{ID: "2", Type: "WatchEvent", Payload: json.RawMessage(`{"action":"started"}`)},
+		},
+	}
+
+	if err := cache.Store("https://api.github.com/users/garetonchick/events", entry); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, ok := cache.Load("https://api.github.com/users/garetonchick/events")
+	if !ok {
+		t.Fatalf("Load: ok = false")
+	}
+	if !reflect.DeepEqual(got, entry) {
+		t.Fatalf("Load: got %+v, want %+v", got, entry)
+	}
+}
+
+func TestFSCacheLoadMiss(t *testing.T) {
+	cache, err := NewFSCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFSCache: %v", err)
+	}
+
+	if _, ok := cache.Load("https://api.github.com/users/nobody/events"); ok {
+		t.Fatalf("Load: ok = true for a key that was never stored")
+	}
+}
+
+func TestFSCacheDistinctKeysDontCollide(t *testing.T) {
+	cache, err := NewFSCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFSCache: %v", err)
+	}
+
+	a := CacheEntry{ETag: "a", Events: []Event{{ID: "1", Payload: json.RawMessage(`{}`)}}}
+	b := CacheEntry{ETag: "b", Events: []Event{{ID: "2", Payload: json.RawMessage(`{}`)}}}
+
+	if err := cache.Store("key-a", a); err != nil {
+		t.Fatalf("Store a: %v", err)
+	}
+	if err := cache.Store("key-b", b); err != nil {
+		t.Fatalf("Store b: %v", err)
+	}
+
+	gotA, _ := cache.Load("key-a")
+	gotB, _ := cache.Load("key-b")
+	if !reflect.DeepEqual(gotA, a) {
+		t.Fatalf("Load key-a: got %+v, want %+v", gotA, a)
+	}
+	if !reflect.DeepEqual(gotB, b) {
+		t.Fatalf("Load key-b: got %+v, want %+v", gotB, b)
+	}
+}
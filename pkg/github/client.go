@@ -3,18 +3,35 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
 
-const ENDPOINT_BASE = "https://api.github.com/users/"
+const (
+	githubAPIVersion = "2022-11-28"
+
+	retryBaseDelay    = 500 * time.Millisecond
+	retryCapDelay     = 60 * time.Second
+	defaultMaxRetries = 5
+)
+
+// endpointBase is a var rather than a const so tests can point it at a
+// local httptest.Server.
+var endpointBase = "https://api.github.com/users/"
+
+// ErrUserNotFound is returned by GetUserEvents when GitHub responds 404 for
+// the requested user.
+var ErrUserNotFound = errors.New("github: user not found")
 
 type Actor struct {
 	ID           uint64 `json:"id"`
@@ -51,9 +68,23 @@ type Event struct {
 }
 
 type Client struct {
-	client           *http.Client
+	client *http.Client
+	cache  Cache
+
+	pageLimit  int
+	tokens     []string
+	maxRetries int
+
+	// mu guards only the mutable pacing/token-rotation bookkeeping below,
+	// not the HTTP round-trip or retry sleeps in getWithHeaders, so
+	// concurrent callers sharing a Client (see GetUsersEvents, Watcher)
+	// cooperatively wait on the shared poll interval without serializing
+	// each other's in-flight requests.
+	mu               sync.Mutex
 	lastPollTime     time.Time
 	lastPollInterval time.Duration
+	tokenIdx         int
+	tokenRemaining   map[string]int
 }
 
 type GithubResponseHeaders struct {
@@ -63,45 +94,170 @@ type GithubResponseHeaders struct {
 	XRatelimitUsed      int
 	XRatelimitReset     time.Time
 	XRatelimitResource  string
+	Link                string        `header:"Link"`
+	ETag                string        `header:"ETag"`
+	RetryAfter          time.Duration
 }
 
-func NewClient(client *http.Client) *Client {
-	return &Client{client: client}
+func NewClient(client *http.Client, opts ...ClientOption) *Client {
+	c := &Client{client: client}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *Client) NeedsToWait() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.needsToWaitLocked()
+}
+
+func (c *Client) needsToWaitLocked() time.Duration {
 	return c.lastPollInterval - time.Since(c.lastPollTime)
 }
 
 func (c *Client) Get(ctx context.Context, endpointURL string) (*http.Response, error) {
-	c.waitPollInterval()
-	c.lastPollTime = time.Now()
-	c.lastPollInterval = time.Second
+	resp, _, err := c.getWithHeaders(ctx, endpointURL, nil)
+	return resp, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
-	if err != nil {
-		return nil, err
+// getWithHeaders is like Get but lets the caller set extra request headers
+// (e.g. If-None-Match) and also returns the parsed response headers so
+// callers don't have to re-parse them. Every 403/429 response is treated
+// as a (primary or secondary) rate limit and retried with full-jitter
+// backoff, rotating through the configured token pool first.
+//
+// The HTTP round-trip and retry sleeps below run without holding c.mu, so
+// concurrent callers sharing a Client (see GetUsersEvents, Watcher) only
+// serialize on the brief reserveSlot reservation, not on each other's
+// in-flight requests or backoff.
+func (c *Client) getWithHeaders(ctx context.Context, endpointURL string, reqHeaders map[string]string) (*http.Response, *GithubResponseHeaders, error) {
+	maxRetries := c.maxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
 	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 0; ; attempt++ {
+		if err := c.reserveSlot(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, value := range reqHeaders {
+			req.Header.Set(name, value)
+		}
+		req.Header.Set("X-GitHub-Api-Version", githubAPIVersion)
+		token := c.currentToken()
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		headers, err := parseHTTPHeaders(&resp.Header)
+		if err != nil {
+			resp.Body.Close()
+			return nil, nil, err
+		}
+
+		if token != "" {
+			c.recordTokenRemaining(token, headers.XRatelimitRemaining)
+		}
+
+		if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+			c.recordPollInterval(headers)
+			return resp, headers, nil
+		}
 
-	headers, err := parseHTTPHeaders(&resp.Header)
-	if err != nil {
 		resp.Body.Close()
-		return nil, err
+
+		if attempt >= maxRetries {
+			return nil, nil, fmt.Errorf("github: rate limited after %d retries", attempt)
+		}
+
+		if c.rotateToken() {
+			continue
+		}
+
+		wait := retryDelay(headers, attempt)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
 	}
+}
 
-	c.lastPollInterval = headers.XPollInterval
+// retryDelay is the longest of: the server-requested Retry-After, the time
+// until the primary rate limit resets, and full-jitter exponential backoff.
+func retryDelay(headers *GithubResponseHeaders, attempt int) time.Duration {
+	delay := fullJitterBackoff(attempt)
+	if headers.RetryAfter > delay {
+		delay = headers.RetryAfter
+	}
+	if untilReset := time.Until(headers.XRatelimitReset); untilReset > delay {
+		delay = untilReset
+	}
+	return delay
+}
 
-	if headers.XRatelimitRemaining == 0 {
-		c.lastPollInterval = time.Until(headers.XRatelimitReset)
+// fullJitterBackoff implements AWS-style full jitter:
+// sleep = rand(0, min(cap, base*2^attempt)).
+func fullJitterBackoff(attempt int) time.Duration {
+	backoff := retryBaseDelay * time.Duration(uint64(1)<<uint(attempt))
+	if backoff <= 0 || backoff > retryCapDelay {
+		backoff = retryCapDelay
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// currentToken returns the token the next request should use, or "" if no
+// token pool is configured.
+func (c *Client) currentToken() string {
+	if len(c.tokens) == 0 {
+		return ""
 	}
 
-	return resp, nil
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[c.tokenIdx]
+}
 
+func (c *Client) recordTokenRemaining(token string, remaining int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokenRemaining == nil {
+		c.tokenRemaining = make(map[string]int)
+	}
+	c.tokenRemaining[token] = remaining
+}
+
+// rotateToken switches to the next token in the pool that isn't known to be
+// exhausted, reporting whether it found one.
+func (c *Client) rotateToken() bool {
+	if len(c.tokens) < 2 {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 1; i < len(c.tokens); i++ {
+		idx := (c.tokenIdx + i) % len(c.tokens)
+		token := c.tokens[idx]
+		if remaining, tracked := c.tokenRemaining[token]; !tracked || remaining > 0 {
+			c.tokenIdx = idx
+			return true
+		}
+	}
+
+	return false
 }
 
 // Unmarshals json inside struct pointed by v
@@ -125,23 +281,172 @@ func (c *Client) GetJSON(ctx context.Context, endpointURL string, v any) error {
 	return nil
 }
 
-func (c *Client) GetUserEvents(ctx context.Context, user string) ([]Event, error) {
+type getUserEventsConfig struct {
+	since time.Time
+}
+
+type GetUserEventsOption func(*getUserEventsConfig)
+
+// WithSince stops pagination once an event older than t is seen.
+func WithSince(t time.Time) GetUserEventsOption {
+	return func(cfg *getUserEventsConfig) {
+		cfg.since = t
+	}
+}
+
+// GetUserEvents fetches all pages of /users/{user}/events, following the
+// Link: rel="next" header until it runs out, a WithSince cutoff is reached,
+// or WithPageLimit is hit. If the client has a Cache configured, it sends
+// the cached ETag as If-None-Match; a 304 response returns the cached
+// events unchanged without consuming rate-limit budget, otherwise the new
+// events are merged into the cache, deduplicated by Event.ID.
+func (c *Client) GetUserEvents(ctx context.Context, user string, opts ...GetUserEventsOption) ([]Event, error) {
+	var cfg getUserEventsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	eventsURL, err := c.buildUserEventsURL(user)
 	if err != nil {
 		return nil, err
 	}
+	cacheKey := eventsURL
 
-	var events []Event
-	err = c.GetJSON(ctx, eventsURL, &events)
-	if err != nil {
-		return nil, err
+	var cached CacheEntry
+	if c.cache != nil {
+		cached, _ = c.cache.Load(cacheKey)
 	}
 
-	return events, nil
+	var fetched []Event
+	etag := cached.ETag
+	pageURL := eventsURL
+
+	for page := 0; pageURL != ""; page++ {
+		if c.pageLimit > 0 && page >= c.pageLimit {
+			break
+		}
+
+		var reqHeaders map[string]string
+		if page == 0 && etag != "" {
+			reqHeaders = map[string]string{"If-None-Match": etag}
+		}
+
+		resp, headers, err := c.getWithHeaders(ctx, pageURL, reqHeaders)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			return cached.Events, nil
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, ErrUserNotFound
+		}
+
+		var pageEvents []Event
+		err = json.NewDecoder(resp.Body).Decode(&pageEvents)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		reachedCutoff := false
+		for _, e := range pageEvents {
+			if !cfg.since.IsZero() {
+				createdAt, err := time.Parse(time.RFC3339, e.CreatedAt)
+				if err == nil && createdAt.Before(cfg.since) {
+					reachedCutoff = true
+					continue
+				}
+			}
+			fetched = append(fetched, e)
+		}
+
+		if page == 0 && headers.ETag != "" {
+			etag = headers.ETag
+		}
+
+		if reachedCutoff {
+			break
+		}
+
+		pageURL = parseLinkHeader(headers.Link)["next"]
+	}
+
+	merged := mergeEvents(fetched, cached.Events)
+
+	if c.cache != nil {
+		if err := c.cache.Store(cacheKey, CacheEntry{ETag: etag, Events: merged}); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeEvents prepends fresh events to cached ones, deduplicating by ID and
+// preferring the fresh copy on conflict.
+func mergeEvents(fresh, cached []Event) []Event {
+	seen := make(map[string]bool, len(fresh))
+	merged := make([]Event, 0, len(fresh)+len(cached))
+
+	for _, e := range fresh {
+		if seen[e.ID] {
+			continue
+		}
+		seen[e.ID] = true
+		merged = append(merged, e)
+	}
+
+	for _, e := range cached {
+		if seen[e.ID] {
+			continue
+		}
+		seen[e.ID] = true
+		merged = append(merged, e)
+	}
+
+	return merged
+}
+
+// reserveSlot blocks until it's this caller's turn to send a request under
+// the shared poll interval, then reserves the next slot so other callers
+// sharing this Client queue up behind it. It only holds c.mu long enough to
+// read and advance lastPollTime; the actual wait happens unlocked so it
+// doesn't block concurrent callers' in-flight requests or bookkeeping.
+func (c *Client) reserveSlot(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	wait := c.lastPollInterval - now.Sub(c.lastPollTime)
+	if wait < 0 {
+		wait = 0
+	}
+	c.lastPollTime = now.Add(wait)
+	c.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
 }
 
-func (c *Client) waitPollInterval() {
-	time.Sleep(c.NeedsToWait())
+// recordPollInterval updates the shared poll interval from a successful
+// response's rate-limit headers.
+func (c *Client) recordPollInterval(headers *GithubResponseHeaders) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastPollInterval = headers.XPollInterval
+	if headers.XRatelimitRemaining <= 0 {
+		c.lastPollInterval = time.Until(headers.XRatelimitReset)
+	}
 }
 
 func parseIntHeader(s string) (int, error) {
@@ -180,6 +485,28 @@ func parseDurationHeader(s string) (time.Duration, error) {
 	return time.Duration(nSeconds) * time.Second, nil
 }
 
+// parseRetryAfterHeader parses a Retry-After header, which GitHub sends as
+// either a number of seconds or an HTTP-date.
+func parseRetryAfterHeader(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	if nSeconds, err := strconv.Atoi(s); err == nil {
+		if nSeconds < 0 {
+			return 0, fmt.Errorf("negative Retry-After %q", s)
+		}
+		return time.Duration(nSeconds) * time.Second, nil
+	}
+
+	when, err := http.ParseTime(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Retry-After %q: %w", s, err)
+	}
+
+	return time.Until(when), nil
+}
+
 func field2HeaderName(name string) string {
 	if len(name) == 0 {
 		panic("empty field name")
@@ -215,7 +542,15 @@ func parseHTTPHeaders(headers *http.Header) (*GithubResponseHeaders, error) {
 		}
 
 		headerRawVal := headers.Get(headerName)
-		fmt.Printf("header: %q\n", headerName)
+
+		if fName == "RetryAfter" {
+			value, err := parseRetryAfterHeader(headerRawVal)
+			if err != nil {
+				return nil, err
+			}
+			fVal.Set(reflect.ValueOf(value))
+			continue
+		}
 
 		switch fVal.Interface().(type) {
 		case int:
@@ -247,7 +582,7 @@ func parseHTTPHeaders(headers *http.Header) (*GithubResponseHeaders, error) {
 }
 
 func (c *Client) buildUserEventsURL(user string) (string, error) {
-	eventsURL, err := url.JoinPath(ENDPOINT_BASE, user, "events")
+	eventsURL, err := url.JoinPath(endpointBase, user, "events")
 	if err != nil {
 		return "", err
 	}
@@ -119,7 +119,7 @@ func newTestServer(t *testing.T, settings *serverSettings) *httptest.Server {
 			panic(err)
 		}
 	}))
-	endpointBase = svr.URL
+	endpointBase = svr.URL + "/users"
 	return svr
 }
 
@@ -186,8 +186,75 @@ func TestRatelimit(t *testing.T) {
 	}
 }
 
+func TestGetUsersEventsRatelimit(t *testing.T) {
+	const nUsers = 50
+	requestsPerWindow := 5
+	// newTestServer force-403s once windowRequests exceeds
+	// RatelimitWindowSize itself (see its windowRequests > settings.RatelimitWindowSize
+	// check), so the window is made one second wider than
+	// requestsPerWindow for 5 requests to genuinely land inside one window
+	// via X-Poll-Interval pacing instead of tripping that forced 403 path.
+	windowSize := requestsPerWindow + 1
+
+	users := make([]string, nUsers)
+	userEvents := make(map[string][]Event, nUsers)
+	for i := range nUsers {
+		user := fmt.Sprintf("user%d", i)
+		users[i] = user
+		userEvents[user] = basicUserEvents["garetonchick"]
+	}
+
+	settings := serverSettings{
+		UserEvents:                 userEvents,
+		Ratelimit:                  true,
+		RatelimitWindowSize:        windowSize,
+		RatelimitRequestsPerWindow: requestsPerWindow,
+	}
+	svr := newTestServer(t, &settings)
+	defer svr.Close()
+
+	c := NewClient(http.DefaultClient)
+
+	startTime := time.Now()
+
+	got, err := c.GetUsersEvents(context.Background(), users, WithConcurrency(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != nUsers {
+		t.Fatalf("expected events for %d users, got %d", nUsers, len(got))
+	}
+
+	elapsed := time.Since(startTime)
+	nWindows := (nUsers + requestsPerWindow - 1) / requestsPerWindow
+	expected := time.Duration(windowSize*(nWindows+1)) * time.Second
+	if (elapsed - expected).Abs() > 3*time.Second {
+		t.Fatalf("expected ~%v execution, but got %v execution", expected, elapsed)
+	}
+}
+
 func TestContextCancelling(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "30")
+		rw.WriteHeader(http.StatusForbidden)
+	}))
+	defer svr.Close()
+	endpointBase = svr.URL + "/"
+
+	c := NewClient(http.DefaultClient)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
 
+	start := time.Now()
+	_, err := c.GetUserEvents(ctx, "garetonchick")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("cancellation took too long to propagate: %v", elapsed)
+	}
 }
 
 func TestParseHeaders(t *testing.T) {
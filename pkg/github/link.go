@@ -0,0 +1,38 @@
+package github
+
+import "strings"
+
+// parseLinkHeader parses an RFC 5988 Link header, e.g.
+//
+//	<https://api.github.com/...?page=2>; rel="next", <...>; rel="last"
+//
+// into a map keyed by rel.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+	if header == "" {
+		return links
+	}
+
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		rawURL := strings.TrimSpace(segments[0])
+		rawURL = strings.TrimPrefix(rawURL, "<")
+		rawURL = strings.TrimSuffix(rawURL, ">")
+
+		for _, seg := range segments[1:] {
+			seg = strings.TrimSpace(seg)
+			rel, ok := strings.CutPrefix(seg, `rel="`)
+			if !ok {
+				continue
+			}
+			rel = strings.TrimSuffix(rel, `"`)
+			links[rel] = rawURL
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLinkHeaderEmpty(t *testing.T) {
+	got := parseLinkHeader("")
+	if len(got) != 0 {
+		t.Fatalf("parseLinkHeader(\"\") = %v, want empty map", got)
+	}
+}
+
+func TestParseLinkHeaderMultipleRels(t *testing.T) {
+	header := `<https://api.github.com/users/x/events?page=2>; rel="next", <https://api.github.com/users/x/events?page=5>; rel="last"`
+
+	got := parseLinkHeader(header)
+	want := map[string]string{
+		"next": "https://api.github.com/users/x/events?page=2",
+		"last": "https://api.github.com/users/x/events?page=5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseLinkHeader(%q) = %v, want %v", header, got, want)
+	}
+}
+
+func TestParseLinkHeaderMissingQuotesIsSkipped(t *testing.T) {
+	// rel without quotes doesn't match the `rel="..."` pattern, so the
+	// link-value is silently dropped rather than misparsed.
+	header := `<https://api.github.com/users/x/events?page=2>; rel=next`
+
+	got := parseLinkHeader(header)
+	if len(got) != 0 {
+		t.Fatalf("parseLinkHeader(%q) = %v, want empty map", header, got)
+	}
+}
+
+func TestParseLinkHeaderMissingRelIsSkipped(t *testing.T) {
+	header := `<https://api.github.com/users/x/events?page=2>`
+
+	got := parseLinkHeader(header)
+	if len(got) != 0 {
+		t.Fatalf("parseLinkHeader(%q) = %v, want empty map", header, got)
+	}
+}
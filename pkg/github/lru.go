@@ -0,0 +1,38 @@
+package github
+
+import "container/list"
+
+// lruSet is a bounded set of the most recently added IDs. Once it grows
+// past limit, the least recently touched ID is evicted.
+type lruSet struct {
+	limit int
+	order *list.List
+	index map[string]*list.Element
+}
+
+func newLRUSet(limit int) *lruSet {
+	return &lruSet{
+		limit: limit,
+		order: list.New(),
+		index: make(map[string]*list.Element),
+	}
+}
+
+// Add reports whether id was not already present, inserting it and
+// evicting the oldest entry if the set is now over limit.
+func (s *lruSet) Add(id string) bool {
+	if elem, ok := s.index[id]; ok {
+		s.order.MoveToFront(elem)
+		return false
+	}
+
+	s.index[id] = s.order.PushFront(id)
+
+	if s.order.Len() > s.limit {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.index, oldest.Value.(string))
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type multiConfig struct {
+	concurrency int
+	onUserError func(user string, err error) error
+}
+
+type MultiOption func(*multiConfig)
+
+// WithConcurrency caps how many users' GetUserEvents calls GetUsersEvents
+// has in flight at once. Defaults to runtime.GOMAXPROCS(0).
+func WithConcurrency(n int) MultiOption {
+	return func(cfg *multiConfig) {
+		cfg.concurrency = n
+	}
+}
+
+// OnUserError lets a caller decide whether one user's error should abort
+// the whole GetUsersEvents batch: return nil to skip that user and keep
+// going, or a non-nil error to abort the group with it.
+type OnUserError func(user string, err error) error
+
+// WithOnUserError installs a callback invoked whenever a single user's
+// GetUserEvents call fails.
+func WithOnUserError(f OnUserError) MultiOption {
+	return func(cfg *multiConfig) {
+		cfg.onUserError = f
+	}
+}
+
+// GetUsersEvents fetches events for each of users concurrently, sharing
+// this Client so its requests still cooperatively pace themselves against
+// the shared rate limit (see getWithHeaders) instead of bursting all at
+// once, while still allowing up to WithConcurrency requests in flight at a
+// time. By default, any single user's error aborts the whole batch; pass
+// WithOnUserError to continue past expected failures like a 404.
+func (c *Client) GetUsersEvents(ctx context.Context, users []string, opts ...MultiOption) (map[string][]Event, error) {
+	cfg := multiConfig{concurrency: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.concurrency <= 0 {
+		cfg.concurrency = 1
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.concurrency)
+
+	var mu sync.Mutex
+	results := make(map[string][]Event, len(users))
+
+	for _, user := range users {
+		g.Go(func() error {
+			events, err := c.GetUserEvents(ctx, user)
+			if err != nil {
+				if cfg.onUserError != nil {
+					return cfg.onUserError(user, err)
+				}
+				return err
+			}
+
+			mu.Lock()
+			results[user] = events
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
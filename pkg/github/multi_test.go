@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetUsersEventsOnUserErrorSkipsUser(t *testing.T) {
+	settings := serverSettings{
+		UserEvents: map[string][]Event{
+			"garetonchick": basicUserEvents["garetonchick"],
+			"other":        basicUserEvents["garetonchick"],
+		},
+	}
+	svr := newTestServer(t, &settings)
+	defer svr.Close()
+
+	c := NewClient(http.DefaultClient)
+	users := []string{"garetonchick", "missing", "other"}
+
+	got, err := c.GetUsersEvents(context.Background(), users, WithOnUserError(func(user string, err error) error {
+		if user == "missing" && errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}))
+	if err != nil {
+		t.Fatalf("GetUsersEvents: %v", err)
+	}
+	if _, ok := got["missing"]; ok {
+		t.Fatalf("GetUsersEvents: expected no entry for skipped user %q", "missing")
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetUsersEvents: expected 2 users, got %d: %v", len(got), got)
+	}
+}
+
+func TestGetUsersEventsOnUserErrorAborts(t *testing.T) {
+	settings := serverSettings{
+		UserEvents: map[string][]Event{
+			"garetonchick": basicUserEvents["garetonchick"],
+		},
+	}
+	svr := newTestServer(t, &settings)
+	defer svr.Close()
+
+	c := NewClient(http.DefaultClient)
+	users := []string{"garetonchick", "missing"}
+
+	wantErr := errors.New("abort the batch")
+	_, err := c.GetUsersEvents(context.Background(), users, WithOnUserError(func(user string, err error) error {
+		if user == "missing" {
+			return wantErr
+		}
+		return err
+	}))
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetUsersEvents: got err %v, want %v", err, wantErr)
+	}
+}
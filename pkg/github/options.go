@@ -0,0 +1,42 @@
+package github
+
+// ClientOption configures a Client constructed by NewClient.
+type ClientOption func(*Client)
+
+// WithCache makes GetUserEvents use cache for conditional requests.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithPageLimit caps the number of pages GetUserEvents will follow. 0 (the
+// default) means no limit.
+func WithPageLimit(n int) ClientOption {
+	return func(c *Client) {
+		c.pageLimit = n
+	}
+}
+
+// WithToken authenticates requests with a single personal access token.
+func WithToken(token string) ClientOption {
+	return WithTokenPool([]string{token})
+}
+
+// WithTokenPool authenticates requests with a pool of tokens, rotating to
+// the next one with rate-limit budget left whenever the current one gets
+// rate limited.
+func WithTokenPool(tokens []string) ClientOption {
+	return func(c *Client) {
+		c.tokens = append([]string(nil), tokens...)
+		c.tokenIdx = 0
+	}
+}
+
+// WithMaxRetries caps how many times Get retries a rate-limited request
+// before giving up. The default is 5.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
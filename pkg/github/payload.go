@@ -0,0 +1,288 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CommitAuthor is the lightweight author/committer info embedded in a push commit.
+type CommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Commit is one of the commits carried by a PushEventPayload.
+type Commit struct {
+	SHA      string       `json:"sha"`
+	Author   CommitAuthor `json:"author"`
+	Message  string       `json:"message"`
+	Distinct bool         `json:"distinct"`
+	URL      string       `json:"url"`
+}
+
+type PushEventPayload struct {
+	RepositoryID uint64   `json:"repository_id"`
+	PushID       uint64   `json:"push_id"`
+	Size         int      `json:"size"`
+	DistinctSize int      `json:"distinct_size"`
+	Ref          string   `json:"ref"`
+	Head         string   `json:"head"`
+	Before       string   `json:"before"`
+	Commits      []Commit `json:"commits"`
+}
+
+// PullRequest is the subset of GitHub's pull request object carried by
+// PullRequestEventPayload and PullRequestReviewEventPayload.
+type PullRequest struct {
+	ID        uint64  `json:"id"`
+	Number    int     `json:"number"`
+	State     string  `json:"state"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"`
+	User      Actor   `json:"user"`
+	Merged    bool    `json:"merged"`
+	HTMLURL   string  `json:"html_url"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	ClosedAt  *string `json:"closed_at"`
+	MergedAt  *string `json:"merged_at"`
+}
+
+type PullRequestEventPayload struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+// Issue is the subset of GitHub's issue object carried by IssuesEventPayload
+// and IssueCommentEventPayload.
+type Issue struct {
+	ID        uint64  `json:"id"`
+	Number    int     `json:"number"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"`
+	State     string  `json:"state"`
+	User      Actor   `json:"user"`
+	HTMLURL   string  `json:"html_url"`
+	CreatedAt string  `json:"created_at"`
+	ClosedAt  *string `json:"closed_at"`
+}
+
+type IssuesEventPayload struct {
+	Action string `json:"action"`
+	Issue  Issue  `json:"issue"`
+}
+
+// Comment is the subset of GitHub's issue comment object carried by
+// IssueCommentEventPayload.
+type Comment struct {
+	ID        uint64 `json:"id"`
+	Body      string `json:"body"`
+	User      Actor  `json:"user"`
+	HTMLURL   string `json:"html_url"`
+	CreatedAt string `json:"created_at"`
+}
+
+type IssueCommentEventPayload struct {
+	Action  string  `json:"action"`
+	Issue   Issue   `json:"issue"`
+	Comment Comment `json:"comment"`
+}
+
+type WatchEventPayload struct {
+	Action string `json:"action"`
+}
+
+type CreateEventPayload struct {
+	Ref          string `json:"ref"`
+	RefType      string `json:"ref_type"`
+	MasterBranch string `json:"master_branch"`
+	Description  string `json:"description"`
+	PusherType   string `json:"pusher_type"`
+}
+
+type DeleteEventPayload struct {
+	Ref        string `json:"ref"`
+	RefType    string `json:"ref_type"`
+	PusherType string `json:"pusher_type"`
+}
+
+// Forkee is the repository created by a ForkEvent.
+type Forkee struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Owner    Actor  `json:"owner"`
+	Private  bool   `json:"private"`
+	HTMLURL  string `json:"html_url"`
+	Fork     bool   `json:"fork"`
+}
+
+type ForkEventPayload struct {
+	Forkee Forkee `json:"forkee"`
+}
+
+// Release is the subset of GitHub's release object carried by ReleaseEventPayload.
+type Release struct {
+	ID              uint64 `json:"id"`
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	CreatedAt       string `json:"created_at"`
+	PublishedAt     string `json:"published_at"`
+	Body            string `json:"body"`
+	HTMLURL         string `json:"html_url"`
+	Author          Actor  `json:"author"`
+}
+
+type ReleaseEventPayload struct {
+	Action  string  `json:"action"`
+	Release Release `json:"release"`
+}
+
+// Review is the subset of GitHub's pull request review object carried by
+// PullRequestReviewEventPayload.
+type Review struct {
+	ID          uint64 `json:"id"`
+	User        Actor  `json:"user"`
+	Body        string `json:"body"`
+	State       string `json:"state"`
+	HTMLURL     string `json:"html_url"`
+	SubmittedAt string `json:"submitted_at"`
+	CommitID    string `json:"commit_id"`
+}
+
+type PullRequestReviewEventPayload struct {
+	Action      string      `json:"action"`
+	Review      Review      `json:"review"`
+	PullRequest PullRequest `json:"pull_request"`
+}
+
+// RawPayload wraps the payload of an event type we don't have a struct for.
+type RawPayload struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+// payloadFactories maps an event Type to a constructor for its payload struct.
+var payloadFactories = map[string]func() any{
+	"PushEvent":              func() any { return &PushEventPayload{} },
+	"PullRequestEvent":       func() any { return &PullRequestEventPayload{} },
+	"IssuesEvent":            func() any { return &IssuesEventPayload{} },
+	"IssueCommentEvent":      func() any { return &IssueCommentEventPayload{} },
+	"WatchEvent":             func() any { return &WatchEventPayload{} },
+	"CreateEvent":            func() any { return &CreateEventPayload{} },
+	"DeleteEvent":            func() any { return &DeleteEventPayload{} },
+	"ForkEvent":              func() any { return &ForkEventPayload{} },
+	"ReleaseEvent":           func() any { return &ReleaseEventPayload{} },
+	"PullRequestReviewEvent": func() any { return &PullRequestReviewEventPayload{} },
+}
+
+// DecodePayload decodes e.Payload into the typed struct registered for e.Type.
+// Event types without a registered struct decode into a *RawPayload instead
+// of returning an error.
+func (e *Event) DecodePayload() (any, error) {
+	newPayload, ok := payloadFactories[e.Type]
+	if !ok {
+		return &RawPayload{Type: e.Type, Raw: e.Payload}, nil
+	}
+
+	payload := newPayload()
+	if err := json.Unmarshal(e.Payload, payload); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+
+	return payload, nil
+}
+
+func (e *Event) AsPush() (*PushEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*PushEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsPullRequest() (*PullRequestEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*PullRequestEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsIssues() (*IssuesEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*IssuesEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsIssueComment() (*IssueCommentEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*IssueCommentEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsWatch() (*WatchEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*WatchEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsCreate() (*CreateEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*CreateEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsDelete() (*DeleteEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*DeleteEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsFork() (*ForkEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*ForkEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsRelease() (*ReleaseEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*ReleaseEventPayload)
+	return v, ok
+}
+
+func (e *Event) AsPullRequestReview() (*PullRequestReviewEventPayload, bool) {
+	p, err := e.DecodePayload()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := p.(*PullRequestReviewEventPayload)
+	return v, ok
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodePayloadPush(t *testing.T) {
+	e := &Event{
+		Type:    "PushEvent",
+		Payload: json.RawMessage(`{"ref":"refs/heads/main","head":"abc123","commits":[{"sha":"abc123","message":"fix it"}]}`),
+	}
+
+	got, ok := e.AsPush()
+	if !ok {
+		t.Fatalf("AsPush: ok = false")
+	}
+	if got.Ref != "refs/heads/main" || got.Head != "abc123" {
+		t.Fatalf("AsPush: got %+v", got)
+	}
+	if len(got.Commits) != 1 || got.Commits[0].Message != "fix it" {
+		t.Fatalf("AsPush: got commits %+v", got.Commits)
+	}
+}
+
+func TestDecodePayloadPullRequest(t *testing.T) {
+	e := &Event{
+		Type:    "PullRequestEvent",
+		Payload: json.RawMessage(`{"action":"opened","number":12,"pull_request":{"title":"Add feature"}}`),
+	}
+
+	got, ok := e.AsPullRequest()
+	if !ok {
+		t.Fatalf("AsPullRequest: ok = false")
+	}
+	if got.Action != "opened" || got.Number != 12 || got.PullRequest.Title != "Add feature" {
+		t.Fatalf("AsPullRequest: got %+v", got)
+	}
+
+	if _, ok := e.AsIssues(); ok {
+		t.Fatalf("AsIssues: expected ok = false for a PullRequestEvent")
+	}
+}
+
+func TestDecodePayloadUnregisteredTypeFallsBackToRaw(t *testing.T) {
+	e := &Event{
+		Type:    "GollumEvent",
+		Payload: json.RawMessage(`{"pages":[{"page_name":"Home"}]}`),
+	}
+
+	got, err := e.DecodePayload()
+	if err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+
+	raw, ok := got.(*RawPayload)
+	if !ok {
+		t.Fatalf("DecodePayload: got %T, want *RawPayload", got)
+	}
+	if raw.Type != "GollumEvent" {
+		t.Fatalf("RawPayload.Type = %q, want %q", raw.Type, "GollumEvent")
+	}
+	if string(raw.Raw) != string(e.Payload) {
+		t.Fatalf("RawPayload.Raw = %q, want %q", raw.Raw, e.Payload)
+	}
+}
+
+func TestDecodePayloadMalformedJSON(t *testing.T) {
+	e := &Event{
+		Type:    "PushEvent",
+		Payload: json.RawMessage(`{not json`),
+	}
+
+	if _, err := e.DecodePayload(); err == nil {
+		t.Fatalf("DecodePayload: expected error for malformed JSON")
+	}
+	if _, ok := e.AsPush(); ok {
+		t.Fatalf("AsPush: expected ok = false for malformed JSON")
+	}
+}
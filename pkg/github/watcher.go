@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSubscriberBuffer = 16
+	defaultRecentIDsLimit   = 512
+
+	// minPollBackoff is the shortest wait between retries after a
+	// GetUserEvents error, so a persistent non-terminal failure (a
+	// transport error, say) can't spin the poller against the API.
+	minPollBackoff = time.Second
+)
+
+// Watcher long-polls GetUserEvents for one or more users and fans out newly
+// observed events to subscribers, deduplicating by Event.ID. Subscribers for
+// the same user share a single poller, so watching a user from N places
+// only costs one GitHub poll.
+type Watcher struct {
+	client           *Client
+	subscriberBuffer int
+	recentIDsLimit   int
+
+	mu      sync.Mutex
+	pollers map[string]*userPoller
+
+	// pollWG tracks currently running poll goroutines, letting tests wait
+	// for a Subscribe/cancel sequence's poller to have actually exited
+	// instead of just unregistered itself from pollers.
+	pollWG sync.WaitGroup
+}
+
+// userPoller is the long-poll goroutine and subscriber set for one user.
+type userPoller struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]*subscriber
+}
+
+// subscriber pairs a subscriber's channel with the sync.Once that guards
+// closing it, since both that subscriber's own cancel func and the
+// poller's stop (on a terminal error) can race to close the same channel.
+type subscriber struct {
+	ch   chan Event
+	once sync.Once
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() {
+		close(s.ch)
+	})
+}
+
+type WatcherOption func(*Watcher)
+
+// WithSubscriberBuffer sets each subscriber channel's buffer size. A
+// subscriber that falls behind by more than this many events has further
+// events dropped rather than blocking the poller.
+func WithSubscriberBuffer(n int) WatcherOption {
+	return func(w *Watcher) {
+		w.subscriberBuffer = n
+	}
+}
+
+func NewWatcher(client *Client, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		client:           client,
+		subscriberBuffer: defaultSubscriberBuffer,
+		recentIDsLimit:   defaultRecentIDsLimit,
+		pollers:          make(map[string]*userPoller),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Subscribe starts (or joins) long-polling for user and returns a channel
+// of newly observed events plus a cancel func that unsubscribes. The
+// returned channel is closed once cancel runs.
+func (w *Watcher) Subscribe(user string) (<-chan Event, func()) {
+	var p *userPoller
+	var sub *subscriber
+	var id int
+
+	for {
+		w.mu.Lock()
+		var ok bool
+		p, ok = w.pollers[user]
+		if !ok {
+			ctx, cancel := context.WithCancel(context.Background())
+			p = &userPoller{cancel: cancel, subs: make(map[int]*subscriber)}
+			w.pollers[user] = p
+			w.pollWG.Add(1)
+			go w.poll(ctx, user, p)
+		}
+		w.mu.Unlock()
+
+		sub = &subscriber{ch: make(chan Event, w.subscriberBuffer)}
+
+		p.mu.Lock()
+		if p.subs == nil {
+			// p was torn down by stop() between our lookup above and this
+			// lock; go around and join (or start) a fresh poller instead
+			// of writing into its nil subs map.
+			p.mu.Unlock()
+			continue
+		}
+		id = p.nextID
+		p.nextID++
+		p.subs[id] = sub
+		p.mu.Unlock()
+		break
+	}
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			// sub.close, not a plain close(sub.ch): stop may have already
+			// closed this channel out from under us on a terminal error.
+			sub.close()
+
+			p.mu.Lock()
+			delete(p.subs, id)
+			empty := len(p.subs) == 0
+			p.mu.Unlock()
+
+			if !empty {
+				return
+			}
+
+			w.mu.Lock()
+			if w.pollers[user] == p {
+				delete(w.pollers, user)
+				p.cancel()
+			}
+			w.mu.Unlock()
+		})
+	}
+
+	return sub.ch, cancel
+}
+
+func (w *Watcher) poll(ctx context.Context, user string, p *userPoller) {
+	defer w.pollWG.Done()
+
+	seen := newLRUSet(w.recentIDsLimit)
+
+	for {
+		events, err := w.client.GetUserEvents(ctx, user)
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				w.stop(user, p)
+				return
+			}
+
+			wait := w.client.NeedsToWait()
+			if wait < minPollBackoff {
+				wait = minPollBackoff
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		// GetUserEvents returns newest-first, like the GitHub API; emit
+		// oldest-to-newest so subscribers see events in order.
+		for i := len(events) - 1; i >= 0; i-- {
+			e := events[i]
+			if seen.Add(e.ID) {
+				w.broadcast(p, e)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.client.NeedsToWait()):
+		}
+	}
+}
+
+// stop tears down a poller on a terminal error (e.g. ErrUserNotFound):
+// it's removed from the Watcher and every current subscriber's channel is
+// closed so they observe the poll has ended. Each subscriber's own cancel
+// func (returned by Subscribe) may run concurrently or afterwards; sub.close
+// makes whichever of the two gets there first the one that actually closes
+// the channel.
+func (w *Watcher) stop(user string, p *userPoller) {
+	w.mu.Lock()
+	if w.pollers[user] == p {
+		delete(w.pollers, user)
+	}
+	w.mu.Unlock()
+
+	p.cancel()
+
+	p.mu.Lock()
+	subs := p.subs
+	p.subs = nil
+	p.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// broadcast fans e out to every current subscriber of p, dropping it for
+// any subscriber whose buffer is full instead of blocking the poller.
+func (w *Watcher) broadcast(p *userPoller, e Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, sub := range p.subs {
+		select {
+		case sub.ch <- e:
+		default:
+		}
+	}
+}
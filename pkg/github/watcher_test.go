@@ -0,0 +1,83 @@
+package github
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// TestWatcherSubscribeStopRace subscribes and immediately cancels for a user
+// the server 404s on, so poll's first GetUserEvents call always hits
+// ErrUserNotFound and tears the poller down via stop. Run with -race, this
+// exercises Subscribe finding a poller whose subs map stop has already
+// nilled out, which Subscribe handles by joining a fresh poller instead.
+func TestWatcherSubscribeStopRace(t *testing.T) {
+	settings := serverSettings{UserEvents: map[string][]Event{}}
+	svr := newTestServer(t, &settings)
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient)
+	w := NewWatcher(client, WithSubscriberBuffer(1))
+
+	const user = "ghost"
+	const workers = 20
+	const itersPerWorker = 50
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < itersPerWorker; j++ {
+				_, cancel := w.Subscribe(user)
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// cancel (and stop, on the 404 path) remove a poller from w.pollers as
+	// soon as its last subscriber is gone, without waiting for its poll
+	// goroutine to actually return. Wait for every poller this run started
+	// to actually exit, so none is still reading endpointBase when the
+	// next test's newTestServer call reassigns it.
+	w.pollWG.Wait()
+}
+
+// TestWatcherSubscribeSharesPoller checks the documented sharing behavior:
+// two subscribers to the same user are served by a single poller, and each
+// gets its own channel closed independently by its own cancel func.
+func TestWatcherSubscribeSharesPoller(t *testing.T) {
+	settings := serverSettings{UserEvents: map[string][]Event{"garetonchick": basicUserEvents["garetonchick"]}}
+	svr := newTestServer(t, &settings)
+	defer svr.Close()
+
+	client := NewClient(http.DefaultClient)
+	w := NewWatcher(client)
+
+	ch1, cancel1 := w.Subscribe("garetonchick")
+	ch2, cancel2 := w.Subscribe("garetonchick")
+
+	w.mu.Lock()
+	pollerCount := len(w.pollers)
+	w.mu.Unlock()
+	if pollerCount != 1 {
+		t.Fatalf("expected 1 shared poller, got %d", pollerCount)
+	}
+
+	cancel1()
+	if _, ok := <-ch1; ok {
+		t.Fatalf("ch1: expected channel closed after cancel1")
+	}
+
+	cancel2()
+	if _, ok := <-ch2; ok {
+		t.Fatalf("ch2: expected channel closed after cancel2")
+	}
+
+	// cancel2 cancels the poller's context but doesn't wait for its poll
+	// goroutine to notice and return; wait for it so it isn't still
+	// mid-GetUserEvents, racing the next test's newTestServer call over
+	// the shared endpointBase var, once this test function returns.
+	w.pollWG.Wait()
+}